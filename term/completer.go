@@ -0,0 +1,75 @@
+package term
+
+import "strings"
+
+// PrefixCompleter is an AutoCompleter backed by a trie of whole-line words,
+// suitable for completing commands or other small, fixed vocabularies.
+type PrefixCompleter struct {
+	root *prefixNode
+}
+
+type prefixNode struct {
+	children map[rune]*prefixNode
+	word     bool
+}
+
+func newPrefixNode() *prefixNode {
+	return &prefixNode{children: make(map[rune]*prefixNode)}
+}
+
+// NewPrefixCompleter creates an empty PrefixCompleter. Use Insert to
+// populate it.
+func NewPrefixCompleter() *PrefixCompleter {
+	return &PrefixCompleter{root: newPrefixNode()}
+}
+
+// Insert adds word as a completion candidate.
+func (c *PrefixCompleter) Insert(word string) {
+	n := c.root
+	for _, r := range word {
+		child, ok := n.children[r]
+		if !ok {
+			child = newPrefixNode()
+			n.children[r] = child
+		}
+		n = child
+	}
+	n.word = true
+}
+
+// Complete implements AutoCompleter, returning every inserted word that
+// has the token preceding pos in line as a prefix. pos is a rune offset,
+// not a byte offset, so line is converted to []rune before slicing.
+func (c *PrefixCompleter) Complete(line string, pos int) []string {
+	token := lastToken(string([]rune(line)[:pos]))
+	n := c.root
+	for _, r := range token {
+		child, ok := n.children[r]
+		if !ok {
+			return nil
+		}
+		n = child
+	}
+	var out []string
+	collect(n, token, &out)
+	return out
+}
+
+// collect appends every complete word reachable from n to out, prefixed
+// with prefix.
+func collect(n *prefixNode, prefix string, out *[]string) {
+	if n.word {
+		*out = append(*out, prefix)
+	}
+	for r, child := range n.children {
+		collect(child, prefix+string(r), out)
+	}
+}
+
+// lastToken returns the whitespace-delimited token s ends with.
+func lastToken(s string) string {
+	if i := strings.LastIndexAny(s, " \t"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
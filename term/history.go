@@ -0,0 +1,65 @@
+package term
+
+import "strings"
+
+// History holds a bounded, in-memory list of previously entered lines and
+// the cursor used to walk it with Prev/Next.
+type History struct {
+	max   int
+	lines []string
+	pos   int // index into lines of the entry Prev/Next last returned; len(lines) means "not browsing"
+}
+
+// NewHistory creates a History that retains at most max lines, discarding
+// the oldest once full.
+func NewHistory(max int) *History {
+	return &History{max: max, pos: 0}
+}
+
+// Add appends line to the history and resets Prev/Next browsing to start
+// from the most recent entry. Empty lines are not recorded.
+func (h *History) Add(line string) {
+	if line == "" {
+		return
+	}
+	h.lines = append(h.lines, line)
+	if len(h.lines) > h.max {
+		h.lines = h.lines[len(h.lines)-h.max:]
+	}
+	h.pos = len(h.lines)
+}
+
+// Prev moves one entry further into the past and returns it, or ("", false)
+// if already at the oldest entry.
+func (h *History) Prev() (string, bool) {
+	if h.pos == 0 {
+		return "", false
+	}
+	h.pos--
+	return h.lines[h.pos], true
+}
+
+// Next moves one entry toward the present and returns it, or ("", false) if
+// already at the most recent entry.
+func (h *History) Next() (string, bool) {
+	if h.pos >= len(h.lines)-1 {
+		h.pos = len(h.lines)
+		return "", false
+	}
+	h.pos++
+	return h.lines[h.pos], true
+}
+
+// Search returns the most recent history line containing query as a
+// substring, or "" if query is empty or nothing matches.
+func (h *History) Search(query string) string {
+	if query == "" {
+		return ""
+	}
+	for i := len(h.lines) - 1; i >= 0; i-- {
+		if strings.Contains(h.lines[i], query) {
+			return h.lines[i]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,269 @@
+package term
+
+// SSH terminal-mode opcodes, as encoded in the "encoded terminal modes"
+// field of an SSH pty-req (RFC 4254 section 8). ttyOpVMin/ttyOpVTime use
+// opcodes from the private-use range (160-255) since VMIN/VTIME have no
+// standard SSH opcode but goterm transmits them anyway so they survive a
+// ToSSH/FromSSH round trip. Flags with no opcode here (such as IGNBRK,
+// BRKINT, IXOFF, ONLCR, CSTOPB, CREAD, HUPCL and CLOCAL), Line and Wz are
+// not transmitted and come back cleared.
+const (
+	ttyOpEnd    = 0
+	ttyOpVIntr  = 1
+	ttyOpVQuit  = 2
+	ttyOpVErase = 3
+	ttyOpVKill  = 4
+	ttyOpVEof   = 5
+	ttyOpVEol   = 6
+	ttyOpVEol2  = 7
+	ttyOpVStart = 8
+	ttyOpVStop  = 9
+	ttyOpVSusp  = 10
+
+	ttyOpVReprint = 12
+	ttyOpVWerase  = 13
+	ttyOpVLnext   = 14
+
+	ttyOpVDiscard = 18
+
+	ttyOpIgnpar = 30
+	ttyOpParmrk = 31
+	ttyOpInpck  = 32
+	ttyOpIstrip = 33
+	ttyOpInlcr  = 34
+	ttyOpIgncr  = 35
+	ttyOpIcrnl  = 36
+	ttyOpIxon   = 38
+
+	ttyOpIsig   = 50
+	ttyOpIcanon = 51
+	ttyOpEcho   = 53
+	ttyOpEchoe  = 54
+	ttyOpEchok  = 55
+	ttyOpEchonl = 56
+	ttyOpNoflsh = 57
+	ttyOpTostop = 58
+	ttyOpIexten = 59
+
+	ttyOpOpost = 70
+
+	ttyOpCs7    = 90
+	ttyOpCs8    = 91
+	ttyOpParenb = 92
+	ttyOpParodd = 93
+
+	ttyOpISpeed = 128
+	ttyOpOSpeed = 129
+
+	ttyOpVMin  = 160
+	ttyOpVTime = 161
+)
+
+// ToSSH encodes t as an SSH "encoded terminal modes" byte stream, suitable
+// for the Modelist field of a pty-req channel request.
+func (t Termios) ToSSH() []byte {
+	var buf []byte
+
+	putCc := func(op byte, idx int) {
+		buf = append(buf, op)
+		buf = appendUint32(buf, uint32(t.Cc[idx]))
+	}
+	putBit := func(op byte, flags, bit uint32) {
+		v := uint32(0)
+		if flags&bit != 0 {
+			v = 1
+		}
+		buf = append(buf, op)
+		buf = appendUint32(buf, v)
+	}
+
+	putCc(ttyOpVIntr, VINTR)
+	putCc(ttyOpVQuit, VQUIT)
+	putCc(ttyOpVErase, VERASE)
+	putCc(ttyOpVKill, VKILL)
+	putCc(ttyOpVEof, VEOF)
+	putCc(ttyOpVEol, VEOL)
+	putCc(ttyOpVEol2, VEOL2)
+	putCc(ttyOpVStart, VSTART)
+	putCc(ttyOpVStop, VSTOP)
+	putCc(ttyOpVSusp, VSUSP)
+	putCc(ttyOpVReprint, VREPRINT)
+	putCc(ttyOpVWerase, VWERASE)
+	putCc(ttyOpVLnext, VLNEXT)
+	putCc(ttyOpVDiscard, VDISCARD)
+	putCc(ttyOpVMin, VMIN)
+	putCc(ttyOpVTime, VTIME)
+
+	putBit(ttyOpIgnpar, t.Iflag, IGNPAR)
+	putBit(ttyOpParmrk, t.Iflag, PARMRK)
+	putBit(ttyOpInpck, t.Iflag, INPCK)
+	putBit(ttyOpIstrip, t.Iflag, ISTRIP)
+	putBit(ttyOpInlcr, t.Iflag, INLCR)
+	putBit(ttyOpIgncr, t.Iflag, IGNCR)
+	putBit(ttyOpIcrnl, t.Iflag, ICRNL)
+	putBit(ttyOpIxon, t.Iflag, IXON)
+
+	putBit(ttyOpIsig, t.Lflag, ISIG)
+	putBit(ttyOpIcanon, t.Lflag, ICANON)
+	putBit(ttyOpEcho, t.Lflag, ECHO)
+	putBit(ttyOpEchoe, t.Lflag, ECHOE)
+	putBit(ttyOpEchok, t.Lflag, ECHOK)
+	putBit(ttyOpEchonl, t.Lflag, ECHONL)
+	putBit(ttyOpNoflsh, t.Lflag, NOFLSH)
+	putBit(ttyOpTostop, t.Lflag, TOSTOP)
+	putBit(ttyOpIexten, t.Lflag, IEXTEN)
+
+	putBit(ttyOpOpost, t.Oflag, OPOST)
+
+	putExact := func(op byte, v bool) {
+		buf = append(buf, op)
+		if v {
+			buf = appendUint32(buf, 1)
+		} else {
+			buf = appendUint32(buf, 0)
+		}
+	}
+	csize := t.Cflag & CSIZE
+	putExact(ttyOpCs7, csize == CS7)
+	putExact(ttyOpCs8, csize == CS8)
+	putBit(ttyOpParenb, t.Cflag, PARENB)
+	putBit(ttyOpParodd, t.Cflag, PARODD)
+
+	buf = append(buf, ttyOpISpeed)
+	buf = appendUint32(buf, t.Ispeed)
+	buf = append(buf, ttyOpOSpeed)
+	buf = appendUint32(buf, t.Ospeed)
+
+	buf = append(buf, ttyOpEnd)
+	return buf
+}
+
+// FromSSH resets t and replays an SSH "encoded terminal modes" byte stream
+// (as produced by ToSSH) onto it.
+func (t *Termios) FromSSH(modes []byte) {
+	*t = Termios{}
+
+	setCc := func(idx int, v uint32) { t.Cc[idx] = uint8(v) }
+	setBit := func(flags *uint32, bit uint32, v uint32) {
+		if v != 0 {
+			*flags |= bit
+		}
+	}
+
+	for len(modes) > 0 {
+		op := modes[0]
+		modes = modes[1:]
+		if op == ttyOpEnd {
+			break
+		}
+		v, rest := parseUint32(modes)
+		modes = rest
+
+		switch op {
+		case ttyOpVIntr:
+			setCc(VINTR, v)
+		case ttyOpVQuit:
+			setCc(VQUIT, v)
+		case ttyOpVErase:
+			setCc(VERASE, v)
+		case ttyOpVKill:
+			setCc(VKILL, v)
+		case ttyOpVEof:
+			setCc(VEOF, v)
+		case ttyOpVEol:
+			setCc(VEOL, v)
+		case ttyOpVEol2:
+			setCc(VEOL2, v)
+		case ttyOpVStart:
+			setCc(VSTART, v)
+		case ttyOpVStop:
+			setCc(VSTOP, v)
+		case ttyOpVSusp:
+			setCc(VSUSP, v)
+		case ttyOpVReprint:
+			setCc(VREPRINT, v)
+		case ttyOpVWerase:
+			setCc(VWERASE, v)
+		case ttyOpVLnext:
+			setCc(VLNEXT, v)
+		case ttyOpVDiscard:
+			setCc(VDISCARD, v)
+		case ttyOpVMin:
+			setCc(VMIN, v)
+		case ttyOpVTime:
+			setCc(VTIME, v)
+
+		case ttyOpIgnpar:
+			setBit(&t.Iflag, IGNPAR, v)
+		case ttyOpParmrk:
+			setBit(&t.Iflag, PARMRK, v)
+		case ttyOpInpck:
+			setBit(&t.Iflag, INPCK, v)
+		case ttyOpIstrip:
+			setBit(&t.Iflag, ISTRIP, v)
+		case ttyOpInlcr:
+			setBit(&t.Iflag, INLCR, v)
+		case ttyOpIgncr:
+			setBit(&t.Iflag, IGNCR, v)
+		case ttyOpIcrnl:
+			setBit(&t.Iflag, ICRNL, v)
+		case ttyOpIxon:
+			setBit(&t.Iflag, IXON, v)
+
+		case ttyOpIsig:
+			setBit(&t.Lflag, ISIG, v)
+		case ttyOpIcanon:
+			setBit(&t.Lflag, ICANON, v)
+		case ttyOpEcho:
+			setBit(&t.Lflag, ECHO, v)
+		case ttyOpEchoe:
+			setBit(&t.Lflag, ECHOE, v)
+		case ttyOpEchok:
+			setBit(&t.Lflag, ECHOK, v)
+		case ttyOpEchonl:
+			setBit(&t.Lflag, ECHONL, v)
+		case ttyOpNoflsh:
+			setBit(&t.Lflag, NOFLSH, v)
+		case ttyOpTostop:
+			setBit(&t.Lflag, TOSTOP, v)
+		case ttyOpIexten:
+			setBit(&t.Lflag, IEXTEN, v)
+
+		case ttyOpOpost:
+			setBit(&t.Oflag, OPOST, v)
+
+		case ttyOpCs7:
+			if v != 0 {
+				t.Cflag = (t.Cflag &^ CSIZE) | CS7
+			}
+		case ttyOpCs8:
+			if v != 0 {
+				t.Cflag = (t.Cflag &^ CSIZE) | CS8
+			}
+		case ttyOpParenb:
+			setBit(&t.Cflag, PARENB, v)
+		case ttyOpParodd:
+			setBit(&t.Cflag, PARODD, v)
+
+		case ttyOpISpeed:
+			t.Ispeed = v
+		case ttyOpOSpeed:
+			t.Ospeed = v
+		}
+	}
+}
+
+// appendUint32 appends v to buf as 4 big-endian bytes.
+func appendUint32(buf []byte, v uint32) []byte {
+	return append(buf, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// parseUint32 reads a big-endian uint32 off the front of buf, returning 0
+// if buf is too short.
+func parseUint32(buf []byte) (uint32, []byte) {
+	if len(buf) < 4 {
+		return 0, nil
+	}
+	v := uint32(buf[0])<<24 | uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3])
+	return v, buf[4:]
+}
@@ -0,0 +1,254 @@
+// Package sshpty bridges term's PTY support to server-side SSH sessions,
+// wiring the pty-req/window-change/shell/exec channel requests from
+// golang.org/x/crypto/ssh to term.OpenPTY and the Termios<>SSH conversions
+// in term.Termios.ToSSH/FromSSH.
+package sshpty
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/google/goterm/term"
+)
+
+// PtyRequest holds the parsed fields of an SSH "pty-req" channel request.
+type PtyRequest struct {
+	Term   string
+	Window term.Winsize
+	Modes  term.Termios
+}
+
+// ParsePtyReq decodes the payload of a "pty-req" channel request.
+func ParsePtyReq(payload []byte) (*PtyRequest, error) {
+	var msg struct {
+		Term     string
+		Columns  uint32
+		Rows     uint32
+		Width    uint32
+		Height   uint32
+		Modelist string
+	}
+	if err := ssh.Unmarshal(payload, &msg); err != nil {
+		return nil, err
+	}
+	var modes term.Termios
+	modes.FromSSH([]byte(msg.Modelist))
+	return &PtyRequest{
+		Term: msg.Term,
+		Window: term.Winsize{
+			Row:    uint16(msg.Rows),
+			Col:    uint16(msg.Columns),
+			Xpixel: uint16(msg.Width),
+			Ypixel: uint16(msg.Height),
+		},
+		Modes: modes,
+	}, nil
+}
+
+// ParseWindowChange decodes the payload of a "window-change" channel
+// request.
+func ParseWindowChange(payload []byte) (*term.Winsize, error) {
+	var msg struct {
+		Columns uint32
+		Rows    uint32
+		Width   uint32
+		Height  uint32
+	}
+	if err := ssh.Unmarshal(payload, &msg); err != nil {
+		return nil, err
+	}
+	return &term.Winsize{
+		Row:    uint16(msg.Rows),
+		Col:    uint16(msg.Columns),
+		Xpixel: uint16(msg.Width),
+		Ypixel: uint16(msg.Height),
+	}, nil
+}
+
+// ParseExecCommand decodes the payload of an "exec" channel request.
+func ParseExecCommand(payload []byte) (string, error) {
+	var msg struct{ Command string }
+	if err := ssh.Unmarshal(payload, &msg); err != nil {
+		return "", err
+	}
+	return msg.Command, nil
+}
+
+// RequestPTY is the client-side counterpart of ParsePtyReq: it serializes
+// modes with Termios.ToSSH and sends them as a "pty-req" channel request.
+func RequestPTY(ch ssh.Channel, termName string, ws term.Winsize, modes term.Termios) (bool, error) {
+	payload := ssh.Marshal(struct {
+		Term     string
+		Columns  uint32
+		Rows     uint32
+		Width    uint32
+		Height   uint32
+		Modelist string
+	}{
+		Term:     termName,
+		Columns:  uint32(ws.Col),
+		Rows:     uint32(ws.Row),
+		Width:    uint32(ws.Xpixel),
+		Height:   uint32(ws.Ypixel),
+		Modelist: string(modes.ToSSH()),
+	})
+	return ch.SendRequest("pty-req", true, payload)
+}
+
+// RequestWindowChange is the client-side counterpart of ParseWindowChange.
+func RequestWindowChange(ch ssh.Channel, ws term.Winsize) error {
+	payload := ssh.Marshal(struct {
+		Columns uint32
+		Rows    uint32
+		Width   uint32
+		Height  uint32
+	}{uint32(ws.Col), uint32(ws.Row), uint32(ws.Xpixel), uint32(ws.Ypixel)})
+	_, err := ch.SendRequest("window-change", false, payload)
+	return err
+}
+
+// Session is a PTY-backed process attached to an SSH session channel.
+type Session struct {
+	PTY *term.PTY
+	Cmd *exec.Cmd
+
+	// done carries the error from Cmd.Wait, once reportExit has reported
+	// it to the channel and closed the channel.
+	done chan error
+}
+
+// Close closes the session's PTY.
+func (s *Session) Close() error {
+	return s.PTY.Close()
+}
+
+// forward copies bytes between the PTY master and the channel until either
+// side is closed.
+func (s *Session) forward(ch ssh.Channel) {
+	go io.Copy(ch, s.PTY.Master)
+	io.Copy(s.PTY.Master, ch)
+}
+
+// reportExit waits for s.Cmd to exit, reports its status to ch with an
+// "exit-status" request, and closes ch, the sequence a real SSH client
+// waits for before it considers the session over and hangs up itself.
+func (s *Session) reportExit(ch ssh.Channel) {
+	err := s.Cmd.Wait()
+	ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{exitStatus(err)}))
+	ch.Close()
+	s.done <- err
+}
+
+// attach starts cmd against pty, wrapping the platform-specific attachCmd
+// (sshpty_unix.go/sshpty_windows.go) with the parent's-copy-of-the-slave
+// cleanup common to both.
+func attach(pty *term.PTY, cmd *exec.Cmd) (*Session, error) {
+	if err := attachCmd(pty, cmd); err != nil {
+		return nil, err
+	}
+	pty.Slave.Close()
+	return &Session{PTY: pty, Cmd: cmd, done: make(chan error, 1)}, nil
+}
+
+// Serve drives a single SSH session channel to completion: it waits for a
+// pty-req to allocate a PTY and apply the requested terminal modes, starts
+// shellCmd() on "shell" or execCmd(command) on "exec" against that PTY,
+// forwards bytes between the PTY and ch, and applies any subsequent
+// window-change requests. Once the started command exits, it reports the
+// exit status to ch with an "exit-status" request and closes ch, then
+// returns.
+func Serve(ch ssh.Channel, requests <-chan *ssh.Request, shellCmd func() *exec.Cmd, execCmd func(command string) *exec.Cmd) error {
+	var pty *term.PTY
+	var sess *Session
+	defer func() {
+		if pty != nil {
+			pty.Close()
+		}
+	}()
+
+	for req := range requests {
+		ok := true
+		switch req.Type {
+		case "pty-req":
+			if pty != nil {
+				// A second pty-req on the same channel would otherwise leak
+				// the PTY just allocated, since only the last one assigned
+				// ever gets closed.
+				ok = false
+				break
+			}
+			ptyReq, err := ParsePtyReq(req.Payload)
+			if err != nil {
+				ok = false
+				break
+			}
+			pty, err = term.OpenPTY()
+			if err != nil {
+				ok = false
+				break
+			}
+			modes := ptyReq.Modes
+			modes.Wz = ptyReq.Window
+			if err := modes.Set(pty.Slave); err != nil {
+				ok = false
+			}
+			if err := modes.Setwinsz(pty.Slave); err != nil {
+				ok = false
+			}
+
+		case "window-change":
+			if pty == nil {
+				ok = false
+				break
+			}
+			ws, err := ParseWindowChange(req.Payload)
+			if err != nil {
+				ok = false
+				break
+			}
+			wt := term.Termios{Wz: *ws}
+			if err := wt.Setwinsz(pty.Slave); err != nil {
+				ok = false
+			}
+
+		case "shell", "exec":
+			if pty == nil {
+				ok = false
+				break
+			}
+			var cmd *exec.Cmd
+			if req.Type == "exec" {
+				command, err := ParseExecCommand(req.Payload)
+				if err != nil {
+					ok = false
+					break
+				}
+				cmd = execCmd(command)
+			} else {
+				cmd = shellCmd()
+			}
+			started, err := attach(pty, cmd)
+			if err != nil {
+				ok = false
+				break
+			}
+			sess = started
+			go sess.forward(ch)
+			go sess.reportExit(ch)
+
+		default:
+			ok = false
+		}
+		if req.WantReply {
+			req.Reply(ok, nil)
+		}
+	}
+
+	if sess == nil {
+		return errors.New("sshpty: channel closed before a shell or exec request arrived")
+	}
+	return <-sess.done
+}
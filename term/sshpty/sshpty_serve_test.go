@@ -0,0 +1,80 @@
+//go:build linux
+
+package sshpty
+
+import (
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// fakeChannel is a minimal ssh.Channel that records SendRequest calls and
+// Close, for driving Serve without a real SSH connection.
+type fakeChannel struct {
+	mu       sync.Mutex
+	requests []string
+	closed   bool
+}
+
+func (f *fakeChannel) Read(p []byte) (int, error)  { return strings.NewReader("").Read(p) }
+func (f *fakeChannel) Write(p []byte) (int, error) { return len(p), nil }
+
+func (f *fakeChannel) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+func (f *fakeChannel) CloseWrite() error { return nil }
+
+func (f *fakeChannel) SendRequest(name string, wantReply bool, payload []byte) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, name)
+	return true, nil
+}
+
+func (f *fakeChannel) Stderr() io.ReadWriter { return nil }
+
+func (f *fakeChannel) snapshot() (requests []string, closed bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...), f.closed
+}
+
+// TestServeReportsExitStatus checks that Serve sends an "exit-status"
+// request and closes the channel once the started command exits, rather
+// than leaving the session hanging until the remote peer closes first.
+func TestServeReportsExitStatus(t *testing.T) {
+	ch := &fakeChannel{}
+	requests := make(chan *ssh.Request, 2)
+	requests <- &ssh.Request{Type: "pty-req", Payload: ssh.Marshal(struct {
+		Term     string
+		Columns  uint32
+		Rows     uint32
+		Width    uint32
+		Height   uint32
+		Modelist string
+	}{"xterm", 80, 24, 0, 0, ""})}
+	requests <- &ssh.Request{Type: "exec", Payload: ssh.Marshal(struct{ Command string }{"exit 7"})}
+	close(requests)
+
+	execCmd := func(command string) *exec.Cmd { return exec.Command("sh", "-c", command) }
+	err := Serve(ch, requests, nil, execCmd)
+	if err == nil {
+		t.Fatal("Serve returned nil error, want the command's non-zero exit")
+	}
+
+	gotRequests, closed := ch.snapshot()
+	if len(gotRequests) == 0 || gotRequests[len(gotRequests)-1] != "exit-status" {
+		t.Errorf("channel requests = %v, want a trailing \"exit-status\"", gotRequests)
+	}
+	if !closed {
+		t.Error("channel was not closed after the command exited")
+	}
+}
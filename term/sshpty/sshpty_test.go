@@ -0,0 +1,64 @@
+package sshpty
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// TestParsePtyReq checks that a marshaled pty-req payload round-trips
+// through ParsePtyReq.
+func TestParsePtyReq(t *testing.T) {
+	payload := ssh.Marshal(struct {
+		Term     string
+		Columns  uint32
+		Rows     uint32
+		Width    uint32
+		Height   uint32
+		Modelist string
+	}{"xterm-256color", 80, 24, 640, 480, ""})
+
+	req, err := ParsePtyReq(payload)
+	if err != nil {
+		t.Fatalf("ParsePtyReq: %v", err)
+	}
+	if req.Term != "xterm-256color" {
+		t.Errorf("Term = %q, want %q", req.Term, "xterm-256color")
+	}
+	if req.Window.Col != 80 || req.Window.Row != 24 {
+		t.Errorf("Window = %+v, want Col=80 Row=24", req.Window)
+	}
+}
+
+// TestParseWindowChange checks that a marshaled window-change payload
+// round-trips through ParseWindowChange.
+func TestParseWindowChange(t *testing.T) {
+	payload := ssh.Marshal(struct {
+		Columns uint32
+		Rows    uint32
+		Width   uint32
+		Height  uint32
+	}{100, 40, 0, 0})
+
+	ws, err := ParseWindowChange(payload)
+	if err != nil {
+		t.Fatalf("ParseWindowChange: %v", err)
+	}
+	if ws.Col != 100 || ws.Row != 40 {
+		t.Errorf("Winsize = %+v, want Col=100 Row=40", ws)
+	}
+}
+
+// TestParseExecCommand checks that a marshaled exec payload round-trips
+// through ParseExecCommand.
+func TestParseExecCommand(t *testing.T) {
+	payload := ssh.Marshal(struct{ Command string }{"uname -a"})
+
+	cmd, err := ParseExecCommand(payload)
+	if err != nil {
+		t.Fatalf("ParseExecCommand: %v", err)
+	}
+	if cmd != "uname -a" {
+		t.Errorf("Command = %q, want %q", cmd, "uname -a")
+	}
+}
@@ -0,0 +1,40 @@
+//go:build !windows
+
+package sshpty
+
+import (
+	"os/exec"
+	"syscall"
+
+	"github.com/google/goterm/term"
+)
+
+// attachCmd starts cmd with the PTY slave as its stdio and as its
+// controlling tty of a new session.
+func attachCmd(pty *term.PTY, cmd *exec.Cmd) error {
+	cmd.Stdin = pty.Slave
+	cmd.Stdout = pty.Slave
+	cmd.Stderr = pty.Slave
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid:  true,
+		Setctty: true,
+		// Ctty indexes cmd.Stdin/Stdout/Stderr/ExtraFiles in the child, not
+		// the parent's fd number; pty.Slave is cmd.Stdin, so that's 0.
+		Ctty: 0,
+	}
+	return cmd.Start()
+}
+
+// exitStatus extracts the numeric exit status from the error returned by
+// cmd.Wait, for reporting via an SSH "exit-status" request.
+func exitStatus(err error) uint32 {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return uint32(ws.ExitStatus())
+		}
+	}
+	return 1
+}
@@ -0,0 +1,34 @@
+//go:build windows
+
+package sshpty
+
+import (
+	"os/exec"
+
+	"github.com/google/goterm/term"
+)
+
+// attachCmd wires cmd's stdio to pty's pipes.
+//
+// This is a plain pipe redirect, not a true ConPTY attach, the same
+// limitation term.attachCmd documents for Start/StartWithSize: os/exec has
+// no way to hand a child the STARTUPINFOEX attribute a real ConPTY attach
+// would need.
+func attachCmd(pty *term.PTY, cmd *exec.Cmd) error {
+	cmd.Stdin = pty.Slave
+	cmd.Stdout = pty.Slave
+	cmd.Stderr = pty.Slave
+	return cmd.Start()
+}
+
+// exitStatus extracts the numeric exit status from the error returned by
+// cmd.Wait, for reporting via an SSH "exit-status" request.
+func exitStatus(err error) uint32 {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return uint32(exitErr.ExitCode())
+	}
+	return 1
+}
@@ -0,0 +1,52 @@
+package term
+
+import (
+	"os"
+	"os/exec"
+)
+
+// Start opens a new PTY, attaches cmd's stdio to its slave with the slave
+// as the controlling tty of a new session, starts cmd, and returns the
+// master end for the caller to drive it.
+func Start(cmd *exec.Cmd) (*PTY, error) {
+	return StartWithSize(cmd, nil)
+}
+
+// StartWithSize is Start, additionally applying ws as the PTY's window size
+// before cmd is exec'd, if ws is non-nil.
+func StartWithSize(cmd *exec.Cmd, ws *Winsize) (*PTY, error) {
+	pty, err := OpenPTY()
+	if err != nil {
+		return nil, err
+	}
+
+	if ws != nil {
+		if err := Setsize(pty.Slave, ws); err != nil {
+			pty.Close()
+			return nil, err
+		}
+	}
+
+	if err := attachCmd(pty, cmd); err != nil {
+		pty.Close()
+		return nil, err
+	}
+	pty.Slave.Close()
+
+	return pty, nil
+}
+
+// Getsize returns the window size of the terminal attached to f.
+func Getsize(f *os.File) (rows, cols uint16, err error) {
+	var t Termios
+	if err := t.Winsz(f); err != nil {
+		return 0, 0, err
+	}
+	return t.Wz.Row, t.Wz.Col, nil
+}
+
+// Setsize sets the window size of the terminal attached to f.
+func Setsize(f *os.File, ws *Winsize) error {
+	t := Termios{Wz: *ws}
+	return t.Setwinsz(f)
+}
@@ -0,0 +1,75 @@
+//go:build linux
+
+package term
+
+import (
+	"bufio"
+	"os/exec"
+	"testing"
+)
+
+// TestStart spawns cat against a real PTY and checks that Start succeeds
+// (the bug this regression-tests: a wrong Ctty value makes cmd.Start fail)
+// and that a line written to the master comes back echoed by the slave's
+// line discipline.
+func TestStart(t *testing.T) {
+	pty, err := Start(exec.Command("cat"))
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer pty.Close()
+
+	if _, err := pty.Master.Write([]byte("hello\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	got, err := bufio.NewReader(pty.Master).ReadString('\n')
+	if err != nil {
+		t.Fatalf("ReadString: %v", err)
+	}
+	// Start opens the PTY in the kernel's default cooked mode, so the slave's
+	// line discipline echoes the trailing \n back as \r\n.
+	if got != "hello\r\n" {
+		t.Errorf("echoed line = %q, want %q", got, "hello\r\n")
+	}
+}
+
+// TestStartWithSize checks that the requested window size is visible on
+// the PTY once StartWithSize returns.
+func TestStartWithSize(t *testing.T) {
+	ws := &Winsize{Row: 40, Col: 100}
+	pty, err := StartWithSize(exec.Command("cat"), ws)
+	if err != nil {
+		t.Fatalf("StartWithSize: %v", err)
+	}
+	defer pty.Close()
+
+	rows, cols, err := Getsize(pty.Master)
+	if err != nil {
+		t.Fatalf("Getsize: %v", err)
+	}
+	if rows != ws.Row || cols != ws.Col {
+		t.Errorf("Getsize = %d,%d want %d,%d", rows, cols, ws.Row, ws.Col)
+	}
+}
+
+// TestSetsize checks that Setsize followed by Getsize round-trips.
+func TestSetsize(t *testing.T) {
+	pty, err := OpenPTY()
+	if err != nil {
+		t.Fatalf("OpenPTY: %v", err)
+	}
+	defer pty.Close()
+
+	want := &Winsize{Row: 30, Col: 120}
+	if err := Setsize(pty.Master, want); err != nil {
+		t.Fatalf("Setsize: %v", err)
+	}
+	rows, cols, err := Getsize(pty.Master)
+	if err != nil {
+		t.Fatalf("Getsize: %v", err)
+	}
+	if rows != want.Row || cols != want.Col {
+		t.Errorf("Getsize = %d,%d want %d,%d", rows, cols, want.Row, want.Col)
+	}
+}
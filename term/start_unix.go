@@ -0,0 +1,24 @@
+//go:build !windows
+
+package term
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// attachCmd wires cmd's stdio to pty's slave and makes the slave cmd's
+// controlling tty in a new session.
+func attachCmd(pty *PTY, cmd *exec.Cmd) error {
+	cmd.Stdin = pty.Slave
+	cmd.Stdout = pty.Slave
+	cmd.Stderr = pty.Slave
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid:  true,
+		Setctty: true,
+		// Ctty indexes cmd.Stdin/Stdout/Stderr/ExtraFiles in the child, not
+		// the parent's fd number; pty.Slave is cmd.Stdin, so that's 0.
+		Ctty: 0,
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package term
+
+import "os/exec"
+
+// attachCmd wires cmd's stdio to pty's pipes.
+//
+// This is a plain pipe redirect, not a true ConPTY attach: os/exec has no
+// way to pass the STARTUPINFOEX PROC_THREAD_ATTRIBUTE_PSEUDOCONSOLE
+// attribute OpenPTY's hpcon would need for a child to inherit the
+// pseudoconsole directly, so cmd sees ordinary pipes rather than a console.
+func attachCmd(pty *PTY, cmd *exec.Cmd) error {
+	cmd.Stdin = pty.Slave
+	cmd.Stdout = pty.Slave
+	cmd.Stderr = pty.Slave
+	return cmd.Start()
+}
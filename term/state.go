@@ -0,0 +1,55 @@
+package term
+
+import (
+	"os"
+	"runtime"
+)
+
+// State holds the terminal mode for a file descriptor as it was before
+// MakeRaw put it in raw mode, so it can be restored later with Restore.
+type State struct {
+	termios Termios
+}
+
+// fileFromFd wraps a borrowed fd in an *os.File for Attr/Set, which only
+// operate on *os.File. The fd is owned by the caller, not this wrapper, so
+// the finalizer os.NewFile installs to close it on GC is removed: otherwise
+// the wrapper's collection at some later, arbitrary GC cycle would close the
+// caller's fd out from under them.
+func fileFromFd(fd int) *os.File {
+	f := os.NewFile(uintptr(fd), "")
+	runtime.SetFinalizer(f, nil)
+	return f
+}
+
+// GetState returns the current state of the terminal attached to fd so it
+// can be restored later with Restore.
+func GetState(fd int) (*State, error) {
+	t, err := Attr(fileFromFd(fd))
+	if err != nil {
+		return nil, err
+	}
+	return &State{termios: t}, nil
+}
+
+// MakeRaw puts the terminal attached to fd into raw mode and returns its
+// previous state so the caller can restore it with Restore, typically via
+// a single deferred call that stays correct even if the caller's Termios
+// is mutated in place afterward.
+func MakeRaw(fd int) (*State, error) {
+	st, err := GetState(fd)
+	if err != nil {
+		return nil, err
+	}
+	raw := st.termios
+	raw.Raw()
+	if err := raw.Set(fileFromFd(fd)); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Restore reapplies a state previously saved by MakeRaw or GetState to fd.
+func Restore(fd int, s *State) error {
+	return s.termios.Set(fileFromFd(fd))
+}
@@ -0,0 +1,56 @@
+//go:build linux
+
+package term
+
+import (
+	"testing"
+)
+
+// TestMakeRawRestore puts a real PTY slave into raw mode and restores it,
+// checking the ECHO flag (cleared by raw mode, set by the kernel default)
+// round-trips. This also regression-tests that GetState/MakeRaw/Restore
+// don't close the fd out from under the caller: fd is reused after each
+// call here, which would fail with EBADF if the os.File wrapper they build
+// internally got finalized and closed it.
+func TestMakeRawRestore(t *testing.T) {
+	pty, err := OpenPTY()
+	if err != nil {
+		t.Fatalf("OpenPTY: %v", err)
+	}
+	defer pty.Close()
+
+	fd := int(pty.Slave.Fd())
+
+	before, err := GetState(fd)
+	if err != nil {
+		t.Fatalf("GetState: %v", err)
+	}
+	if before.termios.Lflag&ECHO == 0 {
+		t.Fatalf("precondition: slave not in cooked mode, ECHO already clear")
+	}
+
+	saved, err := MakeRaw(fd)
+	if err != nil {
+		t.Fatalf("MakeRaw: %v", err)
+	}
+
+	raw, err := GetState(fd)
+	if err != nil {
+		t.Fatalf("GetState after MakeRaw: %v", err)
+	}
+	if raw.termios.Lflag&ECHO != 0 {
+		t.Errorf("ECHO still set after MakeRaw")
+	}
+
+	if err := Restore(fd, saved); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	after, err := GetState(fd)
+	if err != nil {
+		t.Fatalf("GetState after Restore: %v", err)
+	}
+	if after.termios.Lflag&ECHO == 0 {
+		t.Errorf("ECHO not restored after Restore")
+	}
+}
@@ -0,0 +1,142 @@
+// Package term provides terminal ioctl glue and a line editor.
+//
+// Attr/Set/Winsz/Setwinsz/OpenPTY are implemented for darwin, freebsd,
+// dragonfly, linux, solaris and windows. Other Unix platforms, such as
+// netbsd and openbsd, build against the stubs in termios_unsupported.go,
+// which return errUnsupported.
+package term
+
+import (
+	"io"
+	"os"
+)
+
+// nccs is the size of Termios.Cc on Linux, which is what the struct's
+// layout is modeled on for the direct ioctl(2) use in termios_linux.go.
+const nccs = 19
+
+// Termios holds terminal mode flags, control characters and line speeds,
+// together with the last-known window size. Iflag/Oflag/Cflag/Lflag/Line/Cc
+// mirror struct termios from termios(3); Wz is goterm's own addition so
+// Winsz/Setwinsz have somewhere to keep the window size alongside the rest
+// of a terminal's state.
+type Termios struct {
+	Iflag  uint32
+	Oflag  uint32
+	Cflag  uint32
+	Lflag  uint32
+	Line   uint8
+	Cc     [nccs]uint8
+	Ispeed uint32
+	Ospeed uint32
+	Wz     Winsize
+}
+
+// Winsize describes a terminal's window size, as used by TIOCGWINSZ and
+// TIOCSWINSZ.
+type Winsize struct {
+	Row    uint16
+	Col    uint16
+	Xpixel uint16
+	Ypixel uint16
+}
+
+// PTY is a Master/Slave pseudo-terminal pair, as returned by OpenPTY.
+type PTY struct {
+	Master *os.File
+	Slave  *os.File
+
+	// extra closes any platform-specific handle OpenPTY allocated beyond
+	// Master/Slave (e.g. Windows' ConPTY handle), if any. Close calls it
+	// alongside Master/Slave.
+	extra io.Closer
+}
+
+// Input flags (Termios.Iflag).
+const (
+	IGNBRK = 0000001
+	BRKINT = 0000002
+	IGNPAR = 0000004
+	PARMRK = 0000010
+	INPCK  = 0000020
+	ISTRIP = 0000040
+	INLCR  = 0000100
+	IGNCR  = 0000200
+	ICRNL  = 0000400
+	IXON   = 0002000
+	IXOFF  = 0010000
+)
+
+// Output flags (Termios.Oflag).
+const (
+	OPOST = 0000001
+	ONLCR = 0000004
+)
+
+// Control flags (Termios.Cflag).
+const (
+	CSIZE  = 0000060
+	CS7    = 0000040
+	CS8    = 0000060
+	CSTOPB = 0000100
+	CREAD  = 0000200
+	PARENB = 0000400
+	PARODD = 0001000
+	HUPCL  = 0002000
+	CLOCAL = 0004000
+)
+
+// Local flags (Termios.Lflag).
+const (
+	ISIG   = 0000001
+	ICANON = 0000002
+	ECHO   = 0000010
+	ECHOE  = 0000020
+	ECHOK  = 0000040
+	ECHONL = 0000100
+	NOFLSH = 0000200
+	TOSTOP = 0000400
+	IEXTEN = 0100000
+)
+
+// Indices into Termios.Cc.
+const (
+	VINTR = iota
+	VQUIT
+	VERASE
+	VKILL
+	VEOF
+	VTIME
+	VMIN
+	VSWTC
+	VSTART
+	VSTOP
+	VSUSP
+	VEOL
+	VREPRINT
+	VDISCARD
+	VWERASE
+	VLNEXT
+	VEOL2
+)
+
+// Raw puts t into the mode cfmakeraw(3) describes: no input/output
+// processing, no line editing or signal generation, one byte at a time
+// with no timeout.
+func (t *Termios) Raw() {
+	t.Iflag &^= IGNBRK | BRKINT | PARMRK | ISTRIP | INLCR | IGNCR | ICRNL | IXON
+	t.Oflag &^= OPOST
+	t.Lflag &^= ECHO | ECHONL | ICANON | ISIG | IEXTEN
+	t.Cflag &^= CSIZE | PARENB
+	t.Cflag |= CS8
+	t.Cc[VMIN] = 1
+	t.Cc[VTIME] = 0
+}
+
+// Cook puts t back into the ordinary line-buffered, echoing mode a shell
+// expects its controlling terminal to be in.
+func (t *Termios) Cook() {
+	t.Iflag |= BRKINT | IGNPAR | ISTRIP | ICRNL | IXON
+	t.Oflag |= OPOST
+	t.Lflag |= ISIG | ICANON | ECHO | ECHOE | ECHOK | ECHONL | IEXTEN
+}
@@ -0,0 +1,86 @@
+package term
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// errNoPTSNumber is returned by PTSNumber on platforms with no ioctl for
+// the bare pty number.
+var errNoPTSNumber = errors.New("PTSNumber is not supported on this platform, use PTSName")
+
+// Isatty returns true if file is a tty.
+func Isatty(file *os.File) bool {
+	_, err := Attr(file)
+	return err == nil
+}
+
+// GetPass reads password from a TTY with no echo.
+func GetPass(prompt string, f *os.File, pbuf []byte) ([]byte, error) {
+	t, err := Attr(f)
+	if err != nil {
+		return nil, err
+	}
+	defer t.Set(f)
+	noecho := t
+	noecho.Lflag = noecho.Lflag &^ ECHO
+	if err := noecho.Set(f); err != nil {
+		return nil, err
+	}
+	b := make([]byte, 1, 1)
+	i := 0
+	if _, err := f.Write([]byte(prompt)); err != nil {
+		return nil, err
+	}
+	for ; i < len(pbuf); i++ {
+		if _, err := f.Read(b); err != nil {
+			b[0] = 0
+			clearbuf(pbuf[:i+1])
+		}
+		if b[0] == '\n' || b[0] == '\r' {
+			return pbuf[:i], nil
+		}
+		pbuf[i] = b[0]
+		b[0] = 0
+	}
+	clearbuf(pbuf[:i+1])
+	return nil, errors.New("ran out of bufferspace")
+}
+
+// clearbuf clears out the buffer incase we couldn't read the full password.
+func clearbuf(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Close closes the PTYs that OpenPTY created.
+func (p *PTY) Close() error {
+	slaveErr := errors.New("Slave FD nil")
+	if p.Slave != nil {
+		slaveErr = p.Slave.Close()
+	}
+	masterErr := errors.New("Master FD nil")
+	if p.Master != nil {
+		masterErr = p.Master.Close()
+	}
+	var extraErr error
+	if p.extra != nil {
+		extraErr = p.extra.Close()
+	}
+	if slaveErr != nil || masterErr != nil || extraErr != nil {
+		var errs []string
+		if slaveErr != nil {
+			errs = append(errs, "Slave: "+slaveErr.Error())
+		}
+		if masterErr != nil {
+			errs = append(errs, "Master: "+masterErr.Error())
+		}
+		if extraErr != nil {
+			errs = append(errs, "extra: "+extraErr.Error())
+		}
+		return errors.New(strings.Join(errs, " "))
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package term
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// AutoCompleter is implemented by types that can offer tab-completion
+// candidates for the text currently in a Terminal's edit buffer.
+type AutoCompleter interface {
+	// Complete returns the candidate completions for line up to (and not
+	// including) the rune at pos.
+	Complete(line string, pos int) []string
+}
+
+// FuncFilterInputRune is called with every rune read from the terminal
+// before it reaches the edit buffer. Returning ok == false drops the rune;
+// otherwise the (possibly rewritten) rune returned is used in its place.
+type FuncFilterInputRune func(r rune) (out rune, ok bool)
+
+// Terminal is a line-editing REPL layered on top of a Termios/PTY file,
+// offering history navigation, tab completion and input filtering without
+// pulling in a third-party readline.
+type Terminal struct {
+	f  *os.File
+	in *bufio.Reader
+
+	mu       sync.Mutex
+	prompt   string
+	buf      []rune
+	pos      int
+	history  *History
+	complete AutoCompleter
+	filter   FuncFilterInputRune
+
+	saved   *State
+	started bool
+
+	resize *resizeWatcher
+}
+
+// NewTerminal creates a Terminal that edits lines read from and echoed to f.
+func NewTerminal(f *os.File, prompt string) *Terminal {
+	return &Terminal{
+		f:       f,
+		in:      bufio.NewReader(f),
+		prompt:  prompt,
+		history: NewHistory(500),
+	}
+}
+
+// SetPrompt changes the prompt printed at the start of every line.
+func (t *Terminal) SetPrompt(prompt string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prompt = prompt
+}
+
+// SetBuffer preloads the edit buffer with line, placing the cursor at its end.
+func (t *Terminal) SetBuffer(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = []rune(line)
+	t.pos = len(t.buf)
+}
+
+// SetAutoCompleter installs c as the tab-completion source. A nil c disables
+// completion.
+func (t *Terminal) SetAutoCompleter(c AutoCompleter) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.complete = c
+}
+
+// SetFilterInputRune installs f to inspect and optionally drop or rewrite
+// every rune before it reaches the edit buffer. A nil f disables filtering.
+func (t *Terminal) SetFilterInputRune(f FuncFilterInputRune) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.filter = f
+}
+
+// Start puts the terminal's file in raw mode and begins watching for
+// window-size changes. Callers must call Close to restore the prior state.
+func (t *Terminal) Start() error {
+	st, err := MakeRaw(int(t.f.Fd()))
+	if err != nil {
+		return err
+	}
+	t.saved = st
+	t.started = true
+	t.resize = newResizeWatcher(func() { t.redraw() })
+	return nil
+}
+
+// Close restores the terminal's prior mode and stops watching for
+// window-size changes.
+func (t *Terminal) Close() error {
+	if !t.started {
+		return nil
+	}
+	if t.resize != nil {
+		t.resize.stop()
+		t.resize = nil
+	}
+	t.started = false
+	return Restore(int(t.f.Fd()), t.saved)
+}
+
+// ReadLine reads a single line of input, handling history navigation
+// (Up/Down), incremental history search (Ctrl-R), tab completion and
+// backspace editing, returning the completed line once Enter is pressed.
+// It returns io.EOF if Ctrl-D is pressed on an empty line or if Ctrl-C is
+// pressed, which also discards the line, and the read error if reading
+// from the file fails.
+func (t *Terminal) ReadLine() (string, error) {
+	t.mu.Lock()
+	t.redrawLocked()
+	t.mu.Unlock()
+
+	for {
+		r, _, err := t.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		if t.filter != nil {
+			var ok bool
+			r, ok = t.filter(r)
+			if !ok {
+				continue
+			}
+		}
+
+		t.mu.Lock()
+		switch r {
+		case '\r', '\n':
+			line := string(t.buf)
+			t.buf = nil
+			t.pos = 0
+			t.history.Add(line)
+			fmt.Fprint(t.f, "\r\n")
+			t.mu.Unlock()
+			return line, nil
+		case 4: // Ctrl-D
+			if len(t.buf) == 0 {
+				t.mu.Unlock()
+				return "", io.EOF
+			}
+		case 3: // Ctrl-C
+			t.buf = nil
+			t.pos = 0
+			t.mu.Unlock()
+			return "", io.EOF
+		case 127, 8: // Backspace
+			if t.pos > 0 {
+				t.buf = append(t.buf[:t.pos-1], t.buf[t.pos:]...)
+				t.pos--
+			}
+		case 18: // Ctrl-R: incremental history search
+			if line, ok := t.searchHistoryLocked(); ok {
+				t.buf = []rune(line)
+				t.pos = len(t.buf)
+			}
+		case 9: // Tab
+			t.completeLocked()
+		case 27: // escape sequence, e.g. arrow keys
+			t.handleEscapeLocked()
+		default:
+			t.buf = append(t.buf[:t.pos], append([]rune{r}, t.buf[t.pos:]...)...)
+			t.pos++
+		}
+		t.redrawLocked()
+		t.mu.Unlock()
+	}
+}
+
+// handleEscapeLocked consumes the remainder of a "\x1b[..." CSI sequence and
+// applies Up/Down history navigation. t.mu must be held.
+func (t *Terminal) handleEscapeLocked() {
+	b1, _, err := t.in.ReadRune()
+	if err != nil || b1 != '[' {
+		return
+	}
+	b2, _, err := t.in.ReadRune()
+	// Parameter and intermediate bytes precede the final byte (0x40-0x7e).
+	for err == nil && (b2 < 0x40 || b2 > 0x7e) {
+		b2, _, err = t.in.ReadRune()
+	}
+	if err != nil {
+		return
+	}
+	switch b2 {
+	case 'A': // Up
+		if line, ok := t.history.Prev(); ok {
+			t.buf = []rune(line)
+			t.pos = len(t.buf)
+		}
+	case 'B': // Down
+		if line, ok := t.history.Next(); ok {
+			t.buf = []rune(line)
+			t.pos = len(t.buf)
+		}
+	case 'C': // Right
+		if t.pos < len(t.buf) {
+			t.pos++
+		}
+	case 'D': // Left
+		if t.pos > 0 {
+			t.pos--
+		}
+	}
+}
+
+// completeLocked replaces the whitespace-delimited token before the cursor
+// with the longest common prefix of the AutoCompleter's candidates, or
+// leaves the buffer untouched if there are none or no completer is
+// installed. t.mu must be held.
+func (t *Terminal) completeLocked() {
+	if t.complete == nil {
+		return
+	}
+	candidates := t.complete.Complete(string(t.buf), t.pos)
+	if len(candidates) == 0 {
+		return
+	}
+	common := commonPrefix(candidates)
+	if common == "" {
+		return
+	}
+	start := t.pos - len([]rune(lastToken(string(t.buf[:t.pos]))))
+	tail := append([]rune(common), t.buf[t.pos:]...)
+	t.buf = append(t.buf[:start], tail...)
+	t.pos = start + len([]rune(common))
+}
+
+// searchHistoryLocked runs a minimal incremental reverse-search: each
+// subsequent rune narrows the match, Enter/Ctrl-R accepts it, any other
+// control rune aborts. t.mu must be held; it is released and reacquired
+// around the blocking reads.
+func (t *Terminal) searchHistoryLocked() (string, bool) {
+	query := ""
+	for {
+		fmt.Fprintf(t.f, "\r\x1b[K(reverse-i-search)`%s': %s", query, t.history.Search(query))
+		t.mu.Unlock()
+		r, _, err := t.in.ReadRune()
+		t.mu.Lock()
+		if err != nil {
+			return "", false
+		}
+		switch r {
+		case '\r', '\n', 18:
+			return t.history.Search(query), query != ""
+		case 27:
+			return "", false
+		case 127, 8:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+			}
+		default:
+			if r < ' ' {
+				return "", false
+			}
+			query += string(r)
+		}
+	}
+}
+
+// redraw clears the current line and rewrites the prompt and buffer.
+func (t *Terminal) redraw() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.redrawLocked()
+}
+
+// redrawLocked is redraw without acquiring t.mu. t.mu must be held.
+func (t *Terminal) redrawLocked() {
+	fmt.Fprintf(t.f, "\r\x1b[K%s%s", t.prompt, string(t.buf))
+	if back := len(t.buf) - t.pos; back > 0 {
+		fmt.Fprintf(t.f, "\x1b[%dD", back)
+	}
+}
+
+// commonPrefix returns the longest string that is a prefix of every
+// element of ss.
+func commonPrefix(ss []string) string {
+	if len(ss) == 0 {
+		return ""
+	}
+	prefix := ss[0]
+	for _, s := range ss[1:] {
+		for !strings.HasPrefix(s, prefix) {
+			prefix = prefix[:len(prefix)-1]
+			if prefix == "" {
+				return ""
+			}
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,85 @@
+//go:build linux
+
+package term
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestTerminalReadLine drives Terminal.ReadLine against a real PTY,
+// exercising backspace, input filtering and history recall (Up arrow), and
+// checks both the returned line and the echoed output.
+func TestTerminalReadLine(t *testing.T) {
+	pty, err := OpenPTY()
+	if err != nil {
+		t.Fatalf("OpenPTY: %v", err)
+	}
+	defer pty.Close()
+
+	term := NewTerminal(pty.Slave, "")
+	if err := term.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer term.Close()
+
+	// Uppercase every lowercase letter; backspace, CR and escape-sequence
+	// bytes pass through unchanged.
+	term.SetFilterInputRune(func(r rune) (rune, bool) {
+		if r >= 'a' && r <= 'z' {
+			return r - 32, true
+		}
+		return r, true
+	})
+
+	var mu sync.Mutex
+	var echoed bytes.Buffer
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := pty.Master.Read(buf)
+			if n > 0 {
+				mu.Lock()
+				echoed.Write(buf[:n])
+				mu.Unlock()
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// "abc", backspace (drops "c"), "d", Enter -> filtered and edited to
+	// "ABD".
+	if _, err := pty.Master.Write([]byte("abc\x7fd\r")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	line, err := term.ReadLine()
+	if err != nil {
+		t.Fatalf("ReadLine: %v", err)
+	}
+	if line != "ABD" {
+		t.Fatalf("ReadLine() = %q, want %q", line, "ABD")
+	}
+
+	// Up arrow recalls "ABD" from history; Enter accepts it unchanged.
+	if _, err := pty.Master.Write([]byte("\x1b[A\r")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	line, err = term.ReadLine()
+	if err != nil {
+		t.Fatalf("ReadLine: %v", err)
+	}
+	if line != "ABD" {
+		t.Fatalf("ReadLine() (history recall) = %q, want %q", line, "ABD")
+	}
+
+	mu.Lock()
+	got := echoed.String()
+	mu.Unlock()
+	if !strings.Contains(got, "ABD") {
+		t.Errorf("echoed output = %q, want it to contain %q", got, "ABD")
+	}
+}
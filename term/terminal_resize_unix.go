@@ -0,0 +1,43 @@
+//go:build !windows
+
+package term
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// resizeWatcher invokes a callback on SIGWINCH so a Terminal can rewrap its
+// display when the window changes, until stopped.
+type resizeWatcher struct {
+	sig  chan os.Signal
+	done chan struct{}
+}
+
+// newResizeWatcher starts watching for SIGWINCH and calls onResize, from a
+// dedicated goroutine, each time one arrives.
+func newResizeWatcher(onResize func()) *resizeWatcher {
+	w := &resizeWatcher{
+		sig:  make(chan os.Signal, 1),
+		done: make(chan struct{}),
+	}
+	signal.Notify(w.sig, syscall.SIGWINCH)
+	go func() {
+		for {
+			select {
+			case <-w.sig:
+				onResize()
+			case <-w.done:
+				return
+			}
+		}
+	}()
+	return w
+}
+
+// stop stops watching for SIGWINCH.
+func (w *resizeWatcher) stop() {
+	signal.Stop(w.sig)
+	close(w.done)
+}
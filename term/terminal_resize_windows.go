@@ -0,0 +1,17 @@
+//go:build windows
+
+package term
+
+// resizeWatcher is a no-op on Windows: consoles have no SIGWINCH, and
+// resize notification for a ConPTY requires the host to call
+// ResizePseudoConsole explicitly rather than Terminal rewrapping on signal.
+type resizeWatcher struct{}
+
+// newResizeWatcher returns a resizeWatcher that does nothing; onResize is
+// unused on this platform.
+func newResizeWatcher(onResize func()) *resizeWatcher {
+	return &resizeWatcher{}
+}
+
+// stop is a no-op.
+func (w *resizeWatcher) stop() {}
@@ -0,0 +1,62 @@
+package term
+
+import "testing"
+
+// TestHistory checks Add/Prev/Next browsing order, eviction and Search.
+func TestHistory(t *testing.T) {
+	h := NewHistory(2)
+	h.Add("ls -la")
+	h.Add("cd /tmp")
+	h.Add("pwd") // should evict "ls -la"
+
+	if got, ok := h.Prev(); !ok || got != "pwd" {
+		t.Errorf("Prev() = %q, %v, want %q, true", got, ok, "pwd")
+	}
+	if got, ok := h.Prev(); !ok || got != "cd /tmp" {
+		t.Errorf("Prev() = %q, %v, want %q, true", got, ok, "cd /tmp")
+	}
+	if got, ok := h.Prev(); ok {
+		t.Errorf("Prev() = %q, %v, want _, false (evicted entry)", got, ok)
+	}
+	if got, ok := h.Next(); !ok || got != "pwd" {
+		t.Errorf("Next() = %q, %v, want %q, true", got, ok, "pwd")
+	}
+	if got := h.Search("tmp"); got != "cd /tmp" {
+		t.Errorf("Search(%q) = %q, want %q", "tmp", got, "cd /tmp")
+	}
+}
+
+// TestPrefixCompleter checks that Complete returns only words sharing the
+// in-progress token's prefix.
+func TestPrefixCompleter(t *testing.T) {
+	c := NewPrefixCompleter()
+	for _, w := range []string{"get", "get-all", "set", "show"} {
+		c.Insert(w)
+	}
+	got := c.Complete("g", 1)
+	want := map[string]bool{"get": true, "get-all": true}
+	if len(got) != len(want) {
+		t.Fatalf("Complete(%q) = %v, want keys of %v", "g", got, want)
+	}
+	for _, w := range got {
+		if !want[w] {
+			t.Errorf("Complete(%q) returned unexpected candidate %q", "g", w)
+		}
+	}
+}
+
+// TestPrefixCompleterMultibyte checks that pos, a rune offset, is applied
+// to line as a rune offset rather than a byte offset: a multi-byte rune
+// before the cursor must not desync the two and truncate the token.
+func TestPrefixCompleterMultibyte(t *testing.T) {
+	c := NewPrefixCompleter()
+	c.Insert("get")
+
+	line := "日本語 g"
+	pos := len([]rune(line))
+	got := c.Complete(line, pos)
+	want := []string{"get"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("Complete(%q, %d) = %v, want %v", line, pos, got, want)
+	}
+}
@@ -0,0 +1,117 @@
+//go:build freebsd || dragonfly
+
+package term
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// IOCTL terminal stuff.
+const (
+	TIOCGPTN = 0x4004740f // TIOCGPTN IOCTL used to get the PTY number
+)
+
+// Set Sets terminal t attributes on file.
+//
+// t is copied field-by-field into a unix.Termios rather than reinterpreted
+// with a type conversion: unix.Termios has no Wz field and its c_cc/speed
+// widths are platform-defined, so the two types are not layout-compatible.
+func (t *Termios) Set(file *os.File) error {
+	var ut unix.Termios
+	ut.Iflag = uint32(t.Iflag)
+	ut.Oflag = uint32(t.Oflag)
+	ut.Cflag = uint32(t.Cflag)
+	ut.Lflag = uint32(t.Lflag)
+	for i := 0; i < len(ut.Cc) && i < len(t.Cc); i++ {
+		ut.Cc[i] = t.Cc[i]
+	}
+	ut.Ispeed = uint32(t.Ispeed)
+	ut.Ospeed = uint32(t.Ospeed)
+	return unix.IoctlSetTermios(int(file.Fd()), unix.TIOCSETA, &ut)
+}
+
+// Attr Gets (terminal related) attributes from file.
+func Attr(file *os.File) (Termios, error) {
+	ut, err := unix.IoctlGetTermios(int(file.Fd()), unix.TIOCGETA)
+	if err != nil {
+		return Termios{}, err
+	}
+	var t Termios
+	t.Iflag = uint32(ut.Iflag)
+	t.Oflag = uint32(ut.Oflag)
+	t.Cflag = uint32(ut.Cflag)
+	t.Lflag = uint32(ut.Lflag)
+	for i := 0; i < len(t.Cc) && i < len(ut.Cc); i++ {
+		t.Cc[i] = ut.Cc[i]
+	}
+	t.Ispeed = uint32(ut.Ispeed)
+	t.Ospeed = uint32(ut.Ospeed)
+	return t, nil
+}
+
+// Winsz Fetches the current terminal windowsize.
+func (t *Termios) Winsz(file *os.File) error {
+	ws, err := unix.IoctlGetWinsize(int(file.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return err
+	}
+	t.Wz = Winsize{Row: ws.Row, Col: ws.Col, Xpixel: ws.Xpixel, Ypixel: ws.Ypixel}
+	return nil
+}
+
+// Setwinsz Sets the terminal window size.
+func (t *Termios) Setwinsz(file *os.File) error {
+	ws := &unix.Winsize{Row: t.Wz.Row, Col: t.Wz.Col, Xpixel: t.Wz.Xpixel, Ypixel: t.Wz.Ypixel}
+	return unix.IoctlSetWinsize(int(file.Fd()), unix.TIOCSWINSZ, ws)
+}
+
+// OpenPTY Creates a new Master/Slave PTY pair.
+//
+// FreeBSD/DragonFly grant and unlock the slave implicitly on open(2) of
+// /dev/ptmx, so there is no TIOCSPTLCK step like on Linux; the slave is
+// identified with TIOCGPTN instead, the same as Linux's TIOCGPTN.
+func OpenPTY() (*PTY, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pty := &PTY{Master: master}
+	slaveStr, err := pty.PTSName()
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	pty.Slave, err = os.OpenFile(slaveStr, os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	return pty, nil
+}
+
+// PTSName return the name of the pty.
+func (p *PTY) PTSName() (string, error) {
+	n, err := p.PTSNumber()
+	if err != nil {
+		return "", err
+	}
+	return "/dev/pts/" + strconv.Itoa(int(n)), nil
+}
+
+// PTSNumber return the pty number.
+func (p *PTY) PTSNumber() (uint, error) {
+	var ptyno uint32
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, p.Master.Fd(), uintptr(TIOCGPTN), uintptr(unsafe.Pointer(&ptyno)))
+	if errno != 0 {
+		return 0, errno
+	}
+	return uint(ptyno), nil
+}
@@ -0,0 +1,133 @@
+//go:build darwin
+
+package term
+
+import (
+	"bytes"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// IOCTL terminal stuff.
+const (
+	TIOCPTYGNAME = 0x40807453 // TIOCPTYGNAME fetches the name of the pts slave
+	TIOCPTYGRANT = 0x20007454 // TIOCPTYGRANT grants access to the pts slave
+	TIOCPTYUNLK  = 0x20007452 // TIOCPTYUNLK unlocks the pts slave
+)
+
+// Set Sets terminal t attributes on file.
+//
+// t is copied field-by-field into a unix.Termios rather than reinterpreted
+// with a type conversion: unix.Termios has no Wz field and its c_cc/speed
+// widths are platform-defined, so the two types are not layout-compatible.
+func (t *Termios) Set(file *os.File) error {
+	var ut unix.Termios
+	ut.Iflag = uint64(t.Iflag)
+	ut.Oflag = uint64(t.Oflag)
+	ut.Cflag = uint64(t.Cflag)
+	ut.Lflag = uint64(t.Lflag)
+	for i := 0; i < len(ut.Cc) && i < len(t.Cc); i++ {
+		ut.Cc[i] = t.Cc[i]
+	}
+	ut.Ispeed = uint64(t.Ispeed)
+	ut.Ospeed = uint64(t.Ospeed)
+	return unix.IoctlSetTermios(int(file.Fd()), unix.TIOCSETA, &ut)
+}
+
+// Attr Gets (terminal related) attributes from file.
+func Attr(file *os.File) (Termios, error) {
+	ut, err := unix.IoctlGetTermios(int(file.Fd()), unix.TIOCGETA)
+	if err != nil {
+		return Termios{}, err
+	}
+	var t Termios
+	t.Iflag = uint32(ut.Iflag)
+	t.Oflag = uint32(ut.Oflag)
+	t.Cflag = uint32(ut.Cflag)
+	t.Lflag = uint32(ut.Lflag)
+	for i := 0; i < len(t.Cc) && i < len(ut.Cc); i++ {
+		t.Cc[i] = ut.Cc[i]
+	}
+	t.Ispeed = uint32(ut.Ispeed)
+	t.Ospeed = uint32(ut.Ospeed)
+	return t, nil
+}
+
+// Winsz Fetches the current terminal windowsize.
+func (t *Termios) Winsz(file *os.File) error {
+	ws, err := unix.IoctlGetWinsize(int(file.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return err
+	}
+	t.Wz = Winsize{Row: ws.Row, Col: ws.Col, Xpixel: ws.Xpixel, Ypixel: ws.Ypixel}
+	return nil
+}
+
+// Setwinsz Sets the terminal window size.
+func (t *Termios) Setwinsz(file *os.File) error {
+	ws := &unix.Winsize{Row: t.Wz.Row, Col: t.Wz.Col, Xpixel: t.Wz.Xpixel, Ypixel: t.Wz.Ypixel}
+	return unix.IoctlSetWinsize(int(file.Fd()), unix.TIOCSWINSZ, ws)
+}
+
+// OpenPTY Creates a new Master/Slave PTY pair.
+//
+// Darwin has no TIOCGPTN/TIOCSPTLCK; the slave is unlocked and its name is
+// fetched with TIOCPTYUNLK/TIOCPTYGNAME instead of the Linux grantpt dance.
+func OpenPTY() (*PTY, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := unix.IoctlSetInt(int(master.Fd()), TIOCPTYGRANT, 0); err != nil {
+		master.Close()
+		return nil, err
+	}
+	if err := unix.IoctlSetInt(int(master.Fd()), TIOCPTYUNLK, 0); err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	pty := &PTY{Master: master}
+	slaveStr, err := pty.PTSName()
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	pty.Slave, err = os.OpenFile(slaveStr, os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	return pty, nil
+}
+
+// PTSName return the name of the pty.
+//
+// golang.org/x/sys/unix has no TIOCPTYGNAME helper, so this issues the
+// ioctl directly, the same way termios_linux.go does for its own ioctls.
+func (p *PTY) PTSName() (string, error) {
+	var buf [128]byte
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, p.Master.Fd(), uintptr(TIOCPTYGNAME), uintptr(unsafe.Pointer(&buf[0])))
+	if errno != 0 {
+		return "", errno
+	}
+	if n := bytes.IndexByte(buf[:], 0); n >= 0 {
+		return string(buf[:n]), nil
+	}
+	return string(buf[:]), nil
+}
+
+// PTSNumber return the pty number.
+//
+// Darwin exposes no ioctl for the bare integer; the slave's device path
+// already identifies it, so callers that need a number should parse
+// PTSName() instead.
+func (p *PTY) PTSNumber() (uint, error) {
+	return 0, errNoPTSNumber
+}
@@ -0,0 +1,140 @@
+//go:build solaris
+
+package term
+
+// #include <stdlib.h>
+// #include <stropts.h>
+// #include <sys/ioctl.h>
+//
+// // ioctl(3C) is variadic, which cgo cannot call directly; this wrapper
+// // gives the I_PUSH case a fixed signature cgo can bind to.
+// static int push_module(int fd, char *name) {
+//     return ioctl(fd, I_PUSH, name);
+// }
+import "C"
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Set Sets terminal t attributes on file.
+func (t *Termios) Set(file *os.File) error {
+	var ut unix.Termios
+	ut.Iflag = uint32(t.Iflag)
+	ut.Oflag = uint32(t.Oflag)
+	ut.Cflag = uint32(t.Cflag)
+	ut.Lflag = uint32(t.Lflag)
+	for i := 0; i < len(ut.Cc) && i < len(t.Cc); i++ {
+		ut.Cc[i] = t.Cc[i]
+	}
+	return unix.IoctlSetTermios(int(file.Fd()), unix.TCSETS, &ut)
+}
+
+// Attr Gets (terminal related) attributes from file.
+func Attr(file *os.File) (Termios, error) {
+	ut, err := unix.IoctlGetTermios(int(file.Fd()), unix.TCGETS)
+	if err != nil {
+		return Termios{}, err
+	}
+	var t Termios
+	t.Iflag = uint32(ut.Iflag)
+	t.Oflag = uint32(ut.Oflag)
+	t.Cflag = uint32(ut.Cflag)
+	t.Lflag = uint32(ut.Lflag)
+	for i := 0; i < len(t.Cc) && i < len(ut.Cc); i++ {
+		t.Cc[i] = ut.Cc[i]
+	}
+	return t, nil
+}
+
+// Winsz Fetches the current terminal windowsize.
+func (t *Termios) Winsz(file *os.File) error {
+	ws, err := unix.IoctlGetWinsize(int(file.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return err
+	}
+	t.Wz = Winsize{Row: ws.Row, Col: ws.Col, Xpixel: ws.Xpixel, Ypixel: ws.Ypixel}
+	return nil
+}
+
+// Setwinsz Sets the terminal window size.
+func (t *Termios) Setwinsz(file *os.File) error {
+	ws := &unix.Winsize{Row: t.Wz.Row, Col: t.Wz.Col, Xpixel: t.Wz.Xpixel, Ypixel: t.Wz.Ypixel}
+	return unix.IoctlSetWinsize(int(file.Fd()), unix.TIOCSWINSZ, ws)
+}
+
+// OpenPTY Creates a new Master/Slave PTY pair.
+//
+// Solaris/illumos have no TIOCSPTLCK: grantpt(3C)/unlockpt(3C)/ptsname(3C)
+// are libc calls rather than ioctls, so they're made here via cgo, and the
+// slave needs the ptem and ldterm STREAMS modules pushed onto it with
+// I_PUSH before it behaves like a tty.
+func OpenPTY() (*PTY, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret, err := C.grantpt(C.int(master.Fd())); ret != 0 {
+		master.Close()
+		return nil, err
+	}
+	if ret, err := C.unlockpt(C.int(master.Fd())); ret != 0 {
+		master.Close()
+		return nil, err
+	}
+
+	pty := &PTY{Master: master}
+	slaveStr, err := pty.PTSName()
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	pty.Slave, err = os.OpenFile(slaveStr, os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	if err := pushStreamsModule(pty.Slave, "ptem"); err != nil {
+		pty.Close()
+		return nil, err
+	}
+	if err := pushStreamsModule(pty.Slave, "ldterm"); err != nil {
+		pty.Close()
+		return nil, err
+	}
+
+	return pty, nil
+}
+
+// pushStreamsModule pushes the named STREAMS module onto file with I_PUSH.
+func pushStreamsModule(file *os.File, name string) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	if ret, err := C.push_module(C.int(file.Fd()), cname); ret != 0 {
+		return err
+	}
+	return nil
+}
+
+// PTSName return the name of the pty.
+func (p *PTY) PTSName() (string, error) {
+	name, err := C.ptsname(C.int(p.Master.Fd()))
+	if name == nil {
+		return "", err
+	}
+	return C.GoString(name), nil
+}
+
+// PTSNumber return the pty number.
+//
+// Solaris identifies the slave by its ptsname(3C) path rather than a bare
+// integer; there is no TIOCGPTN-equivalent ioctl here.
+func (p *PTY) PTSNumber() (uint, error) {
+	return 0, errNoPTSNumber
+}
@@ -0,0 +1,37 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !solaris && !windows
+
+package term
+
+import (
+	"errors"
+	"os"
+)
+
+// errUnsupported is returned by Attr/Set/Winsz/Setwinsz/OpenPTY on
+// platforms with no termios_*.go glue of their own.
+var errUnsupported = errors.New("term: not implemented on this platform")
+
+// Set Sets terminal t attributes on file.
+func (t *Termios) Set(file *os.File) error {
+	return errUnsupported
+}
+
+// Attr Gets (terminal related) attributes from file.
+func Attr(file *os.File) (Termios, error) {
+	return Termios{}, errUnsupported
+}
+
+// Winsz Fetches the current terminal windowsize.
+func (t *Termios) Winsz(file *os.File) error {
+	return errUnsupported
+}
+
+// Setwinsz Sets the terminal window size.
+func (t *Termios) Setwinsz(file *os.File) error {
+	return errUnsupported
+}
+
+// OpenPTY Creates a new Master/Slave PTY pair.
+func OpenPTY() (*PTY, error) {
+	return nil, errUnsupported
+}
@@ -0,0 +1,126 @@
+//go:build windows
+
+package term
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Windows consoles have no termios; Attr/Set approximate the POSIX flags
+// goterm's callers rely on (echo, canonical/line mode, signal generation) by
+// folding the console mode bits into Termios.Lflag. Iflag/Oflag/Cflag and
+// Cc are left zeroed since the console API has no equivalent knobs for them.
+const (
+	lEcho   = windows.ENABLE_ECHO_INPUT
+	lICanon = windows.ENABLE_LINE_INPUT
+	lISig   = windows.ENABLE_PROCESSED_INPUT
+)
+
+// Set Sets terminal t attributes on file.
+func (t *Termios) Set(file *os.File) error {
+	h := windows.Handle(file.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(h, &mode); err != nil {
+		return err
+	}
+	mode = setFlag(mode, lEcho, t.Lflag&ECHO != 0)
+	mode = setFlag(mode, lICanon, t.Lflag&ICANON != 0)
+	mode = setFlag(mode, lISig, t.Lflag&ISIG != 0)
+	return windows.SetConsoleMode(h, mode)
+}
+
+// Attr Gets (terminal related) attributes from file.
+func Attr(file *os.File) (Termios, error) {
+	var t Termios
+	var mode uint32
+	if err := windows.GetConsoleMode(windows.Handle(file.Fd()), &mode); err != nil {
+		return t, err
+	}
+	if mode&lEcho != 0 {
+		t.Lflag |= ECHO
+	}
+	if mode&lICanon != 0 {
+		t.Lflag |= ICANON
+	}
+	if mode&lISig != 0 {
+		t.Lflag |= ISIG
+	}
+	return t, nil
+}
+
+// setFlag sets or clears bit in mode depending on on.
+func setFlag(mode, bit uint32, on bool) uint32 {
+	if on {
+		return mode | bit
+	}
+	return mode &^ bit
+}
+
+// Winsz Fetches the current terminal windowsize.
+func (t *Termios) Winsz(file *os.File) error {
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(file.Fd()), &info); err != nil {
+		return err
+	}
+	t.Wz = Winsize{
+		Row: uint16(info.Window.Bottom - info.Window.Top + 1),
+		Col: uint16(info.Window.Right - info.Window.Left + 1),
+	}
+	return nil
+}
+
+// Setwinsz Sets the terminal window size.
+//
+// There is no direct SetConsoleWindowSize equivalent for a ConPTY; resizing
+// is done by calling ResizePseudoConsole on the handle returned by OpenPTY,
+// which PTY does not expose. Setwinsz is therefore a no-op on Windows and
+// always returns nil.
+func (t *Termios) Setwinsz(file *os.File) error {
+	return nil
+}
+
+// OpenPTY Creates a new Master/Slave PTY pair.
+//
+// Windows has no /dev/ptmx; a ConPTY is created with CreatePseudoConsole
+// over two anonymous pipe pairs. Master is the write end goterm callers use
+// to send input to the console, and Slave is the read end callers use to
+// read the console's output — a ConPTY has no slave-side file a child
+// inherits directly, it is attached via STARTUPINFOEX instead, so this PTY
+// is only useful for driving the console from this process, not for
+// spawning a child against Slave the way the Unix OpenPTY does.
+func OpenPTY() (*PTY, error) {
+	consoleIn, master, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	slave, consoleOut, err := os.Pipe()
+	if err != nil {
+		consoleIn.Close()
+		master.Close()
+		return nil, err
+	}
+
+	var hpcon windows.Handle
+	err = windows.CreatePseudoConsole(windows.Coord{X: 80, Y: 24}, windows.Handle(consoleIn.Fd()), windows.Handle(consoleOut.Fd()), 0, &hpcon)
+	consoleIn.Close()
+	consoleOut.Close()
+	if err != nil {
+		master.Close()
+		slave.Close()
+		return nil, err
+	}
+
+	return &PTY{Master: master, Slave: slave, extra: pseudoConsole(hpcon)}, nil
+}
+
+// pseudoConsole closes a ConPTY handle via ClosePseudoConsole, so PTY.Close
+// can tear it down the same way it closes Master/Slave; without this the
+// conhost.exe backing every OpenPTY would outlive the process.
+type pseudoConsole windows.Handle
+
+func (h pseudoConsole) Close() error {
+	windows.ClosePseudoConsole(windows.Handle(h))
+	return nil
+}